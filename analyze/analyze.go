@@ -0,0 +1,125 @@
+// Package analyze consumes prove.Lexer's token stream and re-tags
+// identifiers found inside requires, ensures and proof blocks with
+// contract-aware token subtypes, so downstream linters, formatters and
+// terminal renderers can color proof obligations differently from
+// ordinary identifiers.
+package analyze
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+
+	"github.com/magnusknutas/prove/chroma-lexer/prove"
+)
+
+// Contract-aware token subtypes. Chroma's own categories top out at Text
+// (8000-8003), so 9000 is unused and far enough away that future chroma
+// releases adding categories won't collide with these.
+const (
+	// NameProofObligation tags an identifier declaring a proof obligation
+	// (an identifier immediately followed by ':' inside a proof block).
+	NameProofObligation chroma.TokenType = 9000 + iota
+	// NameContractRef tags an identifier referenced inside a requires or
+	// ensures expression.
+	NameContractRef
+	// NameProofStep tags an identifier that is part of a proof block's
+	// prose, rather than an obligation name.
+	NameProofStep
+)
+
+// contractKeywords are the KeywordNamespace tokens that open contract
+// scope; the terminating "from" keyword closes it again.
+var contractKeywords = map[string]bool{
+	"ensures":  true,
+	"requires": true,
+	"proof":    true,
+}
+
+// ContextualToken is a chroma.Token annotated with whether it fell inside a
+// requires/ensures/proof block.
+type ContextualToken struct {
+	chroma.Token
+	InContract bool
+}
+
+// LexWithContext tokenises src with prove.Lexer and re-tags identifiers
+// found inside requires/ensures/proof blocks, tracking scope entry on a
+// contract KeywordNamespace token and exit on the terminating "from"
+// keyword. Only a contract keyword at the start of its line opens or
+// closes scope, so the same words appearing in a proof block's free-form
+// prose (e.g. "requires clause guarantees stock sufficiency") don't flip
+// the state machine.
+func LexWithContext(src string) ([]ContextualToken, error) {
+	it, err := prove.Lexer.Tokenise(nil, src)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tokens      []ContextualToken
+		inContract  bool
+		inProof     bool
+		atLineStart = true
+	)
+
+	for tok := it(); tok != chroma.EOF; tok = it() {
+		isLineStart := atLineStart
+		switch {
+		case tok.Type == chroma.Text && strings.Contains(tok.Value, "\n"):
+			atLineStart = true
+		case strings.TrimSpace(tok.Value) != "":
+			atLineStart = false
+		}
+
+		switch {
+		case isLineStart && tok.Type == chroma.KeywordNamespace && contractKeywords[tok.Value]:
+			inContract = true
+			inProof = tok.Value == "proof"
+		case isLineStart && tok.Type == chroma.Keyword && tok.Value == "from":
+			inContract = false
+			inProof = false
+		}
+
+		ct := ContextualToken{Token: tok, InContract: inContract}
+		switch {
+		case inContract && inProof && tok.Type == chroma.NameAttribute:
+			ct.Type = NameProofObligation
+		case inContract && inProof && tok.Type == chroma.Name:
+			ct.Type = NameProofStep
+		case inContract && !inProof && tok.Type == chroma.Name:
+			ct.Type = NameContractRef
+		}
+
+		tokens = append(tokens, ct)
+	}
+
+	return tokens, nil
+}
+
+// UnreferencedObligations returns, in sorted order, the names of proof
+// obligations declared in a proof block that are never referenced anywhere
+// else in the contract, so a linter can warn about dead proof steps.
+func UnreferencedObligations(tokens []ContextualToken) []string {
+	declared := map[string]bool{}
+	referenced := map[string]bool{}
+
+	for _, tok := range tokens {
+		switch tok.Type {
+		case NameProofObligation:
+			declared[tok.Value] = true
+		case NameContractRef, NameProofStep:
+			referenced[tok.Value] = true
+		}
+	}
+
+	var unreferenced []string
+	for name := range declared {
+		if !referenced[name] {
+			unreferenced = append(unreferenced, name)
+		}
+	}
+	sort.Strings(unreferenced)
+	return unreferenced
+}
@@ -0,0 +1,109 @@
+package analyze
+
+import "testing"
+
+const sample = `transforms calculate_total(items List<OrderItem>, discount Discount, tax TaxRule) Price
+  ensures result >= 0
+  requires len(items) > 0
+  proof
+    subtotal: sums the items Price
+    apply_discount: deduct discount if > 0
+from
+    sub as Price = subtotal(items)
+    sub
+`
+
+func TestLexWithContextTagsProofObligations(t *testing.T) {
+	tokens, err := LexWithContext(sample)
+	if err != nil {
+		t.Fatalf("LexWithContext: %v", err)
+	}
+
+	var obligations []string
+	for _, tok := range tokens {
+		if tok.Type == NameProofObligation {
+			obligations = append(obligations, tok.Value)
+		}
+	}
+
+	want := []string{"subtotal", "apply_discount"}
+	if len(obligations) != len(want) {
+		t.Fatalf("got obligations %v, want %v", obligations, want)
+	}
+	for i, v := range want {
+		if obligations[i] != v {
+			t.Errorf("obligation %d = %q, want %q", i, obligations[i], v)
+		}
+	}
+}
+
+func TestLexWithContextLeavesContractScopeOnFrom(t *testing.T) {
+	tokens, err := LexWithContext(sample)
+	if err != nil {
+		t.Fatalf("LexWithContext: %v", err)
+	}
+
+	// "subtotal" is called again after the terminating "from", where it
+	// must no longer carry the proof-obligation tag it got inside proof.
+	for _, tok := range tokens {
+		if tok.Value == "subtotal" && !tok.InContract && tok.Type == NameProofObligation {
+			t.Errorf("subtotal reference outside the contract block still tagged NameProofObligation")
+		}
+	}
+}
+
+// TestLexWithContextIgnoresContractWordsInProofProse reproduces the
+// place_order example from chroma-lexer/main.go, where the proof block's
+// free-form prose itself contains the word "requires". That occurrence
+// must not be read as leaving the proof block.
+func TestLexWithContextIgnoresContractWordsInProofProse(t *testing.T) {
+	const src = `outputs place_order(db Database, order Order, tax TaxRule) Order!
+  ensures result.status == Confirmed
+  requires fulfillable(order)
+  proof
+    fulfillment: requires clause guarantees stock sufficiency
+from
+    total as Price = calculate_total(order.items, FlatOff(0), tax)
+    confirmed
+`
+
+	tokens, err := LexWithContext(src)
+	if err != nil {
+		t.Fatalf("LexWithContext: %v", err)
+	}
+
+	var proofSteps []string
+	for _, tok := range tokens {
+		if tok.Type == NameProofStep {
+			proofSteps = append(proofSteps, tok.Value)
+		}
+	}
+
+	want := []string{"clause", "guarantees", "stock", "sufficiency"}
+	if len(proofSteps) != len(want) {
+		t.Fatalf("got proof steps %v, want %v", proofSteps, want)
+	}
+	for i, v := range want {
+		if proofSteps[i] != v {
+			t.Errorf("proof step %d = %q, want %q", i, proofSteps[i], v)
+		}
+	}
+}
+
+func TestUnreferencedObligations(t *testing.T) {
+	tokens, err := LexWithContext(sample)
+	if err != nil {
+		t.Fatalf("LexWithContext: %v", err)
+	}
+
+	got := UnreferencedObligations(tokens)
+	want := []string{"apply_discount", "subtotal"}
+	if len(got) != len(want) {
+		t.Fatalf("UnreferencedObligations() = %v, want %v", got, want)
+	}
+	for i, name := range want {
+		if got[i] != name {
+			t.Errorf("UnreferencedObligations()[%d] = %q, want %q", i, got[i], name)
+		}
+	}
+}
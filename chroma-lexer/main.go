@@ -5,9 +5,8 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/alecthomas/chroma"
-	"github.com/alecthomas/chroma/lexers"
-	_ "github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
 
 	"github.com/magnusknutas/prove/chroma-lexer/prove"
 )
@@ -0,0 +1,23 @@
+// Package prove provides a Chroma v2 lexer for the Prove programming
+// language, loaded from an embedded XML lexer definition so it can be
+// picked up through the standard v2 lexer registry (lexers.Register) the
+// way downstream tools such as Hugo, cheat, wakatime-cli and remark42
+// discover Chroma lexers.
+package prove
+
+import (
+	"embed"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+)
+
+//go:embed embedded/prove.xml
+var lexerFS embed.FS
+
+func init() {
+	lexers.Register(Lexer)
+}
+
+// Lexer is the Chroma lexer for the Prove programming language.
+var Lexer = chroma.MustNewXMLLexer(lexerFS, "embedded/prove.xml")
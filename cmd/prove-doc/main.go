@@ -0,0 +1,39 @@
+// Command prove-doc renders a Prove source file's narrative and
+// doc-comments to a browsable HTML page, e.g. inventory.prv -> inventory.html.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/magnusknutas/prove/render/markdown"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: prove-doc <file.prv>")
+		os.Exit(1)
+	}
+
+	src, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prove-doc: %v\n", err)
+		os.Exit(1)
+	}
+
+	html, err := markdown.RenderHTML(string(src))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prove-doc: %v\n", err)
+		os.Exit(1)
+	}
+
+	out := strings.TrimSuffix(os.Args[1], filepath.Ext(os.Args[1])) + ".html"
+	if err := os.WriteFile(out, []byte(html), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "prove-doc: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println(out)
+}
@@ -0,0 +1,42 @@
+// Command prove-render prints a Prove source file to the terminal with
+// syntax-aware highlighting, falling back to plain text when stdout isn't
+// a TTY.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/magnusknutas/prove/render"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: prove-render <file.prv>")
+		os.Exit(1)
+	}
+
+	src, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prove-render: %v\n", err)
+		os.Exit(1)
+	}
+
+	out, err := render.RenderToTerminal(string(src), render.RenderOptions{
+		NoColor: !isTerminal(os.Stdout),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "prove-render: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Print(out)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
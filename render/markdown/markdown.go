@@ -0,0 +1,135 @@
+// Package markdown extracts the Markdown narrative from a Prove source
+// file — its /// doc-comments and narrative: """...""" blocks — and renders
+// it to HTML or a terminal variant, the way bfchroma/glamour bridge
+// blackfriday/goldmark with Chroma. Fenced ```prove code blocks are
+// highlighted with the exact Prove lexer this module registers.
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/renderer/html"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+
+	_ "github.com/magnusknutas/prove/chroma-lexer/prove" // registers the "prove" Chroma lexer
+	"github.com/magnusknutas/prove/render"
+)
+
+var (
+	docCommentLine = regexp.MustCompile(`(?m)^[ \t]*///[ \t]?(.*)$`)
+	narrativeBlock = regexp.MustCompile(`(?s)narrative:\s*"""(.*?)"""`)
+	fence          = regexp.MustCompile("(?s)```prove\n(.*?)```")
+)
+
+// Extract pulls the Markdown narrative out of Prove source: every
+// contiguous run of /// doc-comment lines joined into a paragraph, followed
+// by the contents of any narrative: """...""" blocks, in source order.
+func Extract(src string) string {
+	var blocks []string
+
+	var doc []string
+	flushDoc := func() {
+		if len(doc) > 0 {
+			blocks = append(blocks, strings.Join(doc, "\n"))
+			doc = nil
+		}
+	}
+	for _, line := range strings.Split(src, "\n") {
+		if m := docCommentLine.FindStringSubmatch(line); m != nil {
+			doc = append(doc, m[1])
+			continue
+		}
+		flushDoc()
+	}
+	flushDoc()
+
+	for _, m := range narrativeBlock.FindAllStringSubmatch(src, -1) {
+		blocks = append(blocks, dedent(m[1]))
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+var markdownRenderer = goldmark.New(
+	goldmark.WithExtensions(
+		highlighting.NewHighlighting(
+			highlighting.WithStyle("github"),
+			highlighting.WithFormatOptions(chromahtml.WithLineNumbers(false)),
+		),
+	),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// RenderHTML renders a Prove source file's narrative to HTML, highlighting
+// any fenced ```prove code blocks with the Prove lexer.
+func RenderHTML(src string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdownRenderer.Convert([]byte(Extract(src)), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// RenderTerminal renders a Prove source file's narrative for a terminal,
+// printing prose as-is and highlighting fenced ```prove code blocks with
+// render.RenderToTerminal.
+func RenderTerminal(src string, opts render.RenderOptions) (string, error) {
+	narrative := Extract(src)
+
+	var out strings.Builder
+	last := 0
+	for _, loc := range fence.FindAllStringSubmatchIndex(narrative, -1) {
+		out.WriteString(narrative[last:loc[0]])
+
+		code := narrative[loc[2]:loc[3]]
+		highlighted, err := render.RenderToTerminal(code, opts)
+		if err != nil {
+			return "", err
+		}
+		out.WriteString(highlighted)
+
+		last = loc[1]
+	}
+	out.WriteString(narrative[last:])
+
+	return out.String(), nil
+}
+
+// dedent strips the common leading whitespace shared by every non-blank
+// line of s, the way a """-delimited block's indentation should be
+// stripped before it's read as Markdown.
+func dedent(s string) string {
+	lines := strings.Split(s, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent == -1 || n < indent {
+			indent = n
+		}
+	}
+	if indent <= 0 {
+		return strings.Join(lines, "\n")
+	}
+
+	for i, line := range lines {
+		if len(line) >= indent {
+			lines[i] = line[indent:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
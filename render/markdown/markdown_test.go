@@ -0,0 +1,32 @@
+package markdown
+
+import "testing"
+
+func TestExtractJoinsDocCommentsAndNarrative(t *testing.T) {
+	const src = `/// InventoryService tracks stock levels.
+/// It exposes one endpoint per intent verb.
+module InventoryService
+  narrative: """
+  Products are added to inventory with validated stock levels.
+  """
+
+  type Port is Integer where 1..65535
+`
+
+	const want = "InventoryService tracks stock levels.\n" +
+		"It exposes one endpoint per intent verb.\n\n" +
+		"Products are added to inventory with validated stock levels."
+
+	if got := Extract(src); got != want {
+		t.Errorf("Extract() = %q, want %q", got, want)
+	}
+}
+
+func TestDedentStripsCommonIndent(t *testing.T) {
+	const in = "\n    one\n    two\n"
+	const want = "one\ntwo"
+
+	if got := dedent(in); got != want {
+		t.Errorf("dedent(%q) = %q, want %q", in, got, want)
+	}
+}
@@ -0,0 +1,99 @@
+// Package render turns Prove source into syntax-highlighted terminal output
+// by piping the Chroma token stream from prove.Lexer through a Chroma
+// formatter and style, the same way glamour renders Markdown for a terminal.
+package render
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/styles"
+
+	"github.com/magnusknutas/prove/chroma-lexer/prove"
+)
+
+// Theme names accepted by RenderOptions.Theme.
+const (
+	ThemeMonokai        = "monokai"
+	ThemeSolarizedDark  = "solarized-dark"
+	ThemeSolarizedLight = "solarized-light"
+	ThemeGithub         = "github"
+)
+
+// RenderOptions controls how RenderToTerminal highlights Prove source.
+type RenderOptions struct {
+	// Theme selects the Chroma style to render with. Empty means
+	// auto-detect a light or dark theme from the terminal background.
+	Theme string
+	// NoColor disables ANSI output entirely and returns src unchanged,
+	// e.g. when stdout is not a TTY.
+	NoColor bool
+	// TrueColor requests the 24-bit color formatter instead of the
+	// 256-color one. Ignored when NoColor is set.
+	TrueColor bool
+}
+
+// RenderToTerminal highlights src as Prove source and returns the ANSI
+// escape sequences for a terminal. With opts.NoColor set it returns src
+// unchanged so callers can always funnel output through RenderToTerminal.
+func RenderToTerminal(src string, opts RenderOptions) (string, error) {
+	if opts.NoColor {
+		return src, nil
+	}
+
+	theme := opts.Theme
+	if theme == "" {
+		theme = defaultTheme()
+	}
+	style := styles.Get(theme)
+	if style == nil {
+		style = styles.Fallback
+	}
+
+	formatterName := "terminal256"
+	if opts.TrueColor {
+		formatterName = "terminal16m"
+	}
+	formatter := formatters.Get(formatterName)
+	if formatter == nil {
+		formatter = formatters.Fallback
+	}
+
+	iterator, err := prove.Lexer.Tokenise(nil, src)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := formatter.Format(&buf, style, iterator); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// defaultTheme auto-detects a light or dark terminal background the way
+// termenv's HasDarkBackground does: by reading the COLORFGBG convention
+// exported by most terminal emulators. When that isn't available we assume
+// a dark background, since that's the common case for developer terminals.
+func defaultTheme() string {
+	if hasDarkBackground() {
+		return ThemeMonokai
+	}
+	return ThemeSolarizedLight
+}
+
+func hasDarkBackground() bool {
+	fgbg := os.Getenv("COLORFGBG")
+	if fgbg == "" {
+		return true
+	}
+	parts := strings.Split(fgbg, ";")
+	switch parts[len(parts)-1] {
+	case "0", "8":
+		return true
+	default:
+		return false
+	}
+}